@@ -0,0 +1,5 @@
+package base
+
+type Stoppable interface {
+	Stop()
+}
@@ -0,0 +1,109 @@
+package base
+
+type Stmt struct {
+	Stmt string
+	Data [][]interface{}
+}
+
+type Record []string
+
+type OpType int
+
+const (
+	DONE   OpType = 0
+	INSERT OpType = 1
+	DELETE OpType = 2
+	HEADER OpType = 100
+)
+
+func (op OpType) String() string {
+	switch op {
+	case 0:
+		return "DONE"
+	case 1:
+		return "INSERT"
+	case 2:
+		return "DELETE"
+	case 100:
+		return "HEADER"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+type Data struct {
+	Type   OpType
+	Record Record
+	Bytes  int
+
+	// Headers, Source, and LineNo identify where Record came from in the
+	// source CSV, so a DataWriter can reproduce enough context (column
+	// names, file, line) to re-drive a failed row without re-reading the
+	// original file.
+	Headers []string
+	Source  string
+	LineNo  int64
+}
+
+func InsertData(record Record, bytes int) Data {
+	return Data{
+		Type:   INSERT,
+		Record: record,
+		Bytes:  bytes,
+	}
+}
+
+func DeleteData(record Record, bytes int) Data {
+	return Data{
+		Type:   DELETE,
+		Record: record,
+		Bytes:  bytes,
+	}
+}
+
+func HeaderData(record Record, bytes int) Data {
+	return Data{
+		Type:   HEADER,
+		Record: record,
+		Bytes:  bytes,
+	}
+}
+
+var done = Data{
+	Type:   DONE,
+	Record: nil,
+}
+
+func FinishData() Data {
+	return done
+}
+
+type ErrData struct {
+	Error error
+	Data  []Data
+}
+
+type ResponseData struct {
+	Error error
+	Stats Stats
+}
+
+// ClientRequest is the unit of work handed to a ClientPool worker. Params,
+// when non-empty, carries the named bindings for Stmt and is passed to
+// Session.ExecuteWithParameter instead of re-rendering Stmt as a literal
+// string per row.
+type ClientRequest struct {
+	Stmt   string
+	Params map[string]interface{}
+	ErrCh  chan<- ErrData
+	Data   []Data
+}
+
+const (
+	LABEL_LABEL   = ":LABEL"
+	LABEL_VID     = ":VID"
+	LABEL_SRC_VID = ":SRC_VID"
+	LABEL_DST_VID = ":DST_VID"
+	LABEL_RANK    = ":RANK"
+	LABEL_IGNORE  = ":IGNORE"
+)
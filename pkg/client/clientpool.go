@@ -1,9 +1,14 @@
 package client
 
 import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"os"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
@@ -19,22 +24,45 @@ const (
 	DefaultRetryMultiplier          = 1.5
 	DefaultRetryMaxInterval         = 2 * time.Minute
 	DefaultRetryMaxElapsedTime      = time.Hour
+
+	// DefaultHealthCheckPeriod is how often an idle worker pings its session
+	// so a session killed or timed out by graphd is noticed before the next
+	// real request lands on it.
+	DefaultHealthCheckPeriod = 30 * time.Second
 )
 
+// sessionNotFound is E_SESSION_NOT_FOUND's numeric error code (-2069), which
+// nebula-go's top-level package doesn't export as a named ErrorCode constant
+// (see retrypolicy_test.go's leaderChanged for the same pattern).
+const sessionNotFound = nebula.ErrorCode(-2069)
+
 type ClientPool struct {
-	retry        int
-	concurrency  int
-	space        string
-	postStart    *config.NebulaPostStart
-	preStop      *config.NebulaPreStop
-	statsCh      chan<- base.Stats
-	pool         *nebula.ConnectionPool
-	Sessions     []*nebula.Session
-	requestChs   []chan base.ClientRequest
-	runnerLogger *logger.RunnerLogger
+	ctx               context.Context
+	cancel            context.CancelFunc
+	wg                sync.WaitGroup
+	closeOnce         sync.Once
+	retry             int
+	retryPolicy       *RetryPolicy
+	concurrency       int
+	space             string
+	user              string
+	password          string
+	healthCheckPeriod time.Duration
+	postStart         *config.NebulaPostStart
+	preStop           *config.NebulaPreStop
+	statsCh           chan<- base.Stats
+	pool              *nebula.ConnectionPool
+	Sessions          []*nebula.Session
+	sessionMu         []sync.Mutex
+	requestChs        []chan base.ClientRequest
+	runnerLogger      *logger.RunnerLogger
 }
 
-func NewClientPool(settings *config.NebulaClientSettings, statsCh chan<- base.Stats, runnerLogger *logger.RunnerLogger) (*ClientPool, error) {
+// NewClientPool builds a ClientPool bound to ctx: canceling ctx aborts any
+// in-flight backoff retries and makes idle workers exit. How long Shutdown
+// waits for in-flight statements to drain is bounded by the ctx passed to
+// Shutdown itself, not by this one.
+func NewClientPool(ctx context.Context, settings *config.NebulaClientSettings, statsCh chan<- base.Stats, runnerLogger *logger.RunnerLogger) (*ClientPool, error) {
 	addrs := strings.Split(*settings.Connection.Address, ",")
 	var hosts []nebula.HostAddress
 	for _, addr := range addrs {
@@ -55,27 +83,54 @@ func NewClientPool(settings *config.NebulaClientSettings, statsCh chan<- base.St
 		MaxConnPoolSize: len(addrs) * *settings.Concurrency,
 		MinConnPoolSize: 1,
 	}
-	connPool, err := nebula.NewConnectionPool(hosts, conf, logger.NewNebulaLogger(runnerLogger))
-	if err != nil {
-		return nil, err
+	var (
+		connPool *nebula.ConnectionPool
+		err      error
+	)
+	if tlsConf := settings.Connection.TLS; tlsConf != nil && tlsConf.Enabled != nil && *tlsConf.Enabled {
+		var sslConf *tls.Config
+		sslConf, err = buildTLSConfig(tlsConf)
+		if err != nil {
+			return nil, err
+		}
+		connPool, err = nebula.NewSslConnectionPool(hosts, conf, sslConf, logger.NewNebulaLogger(runnerLogger))
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		connPool, err = nebula.NewConnectionPool(hosts, conf, logger.NewNebulaLogger(runnerLogger))
+		if err != nil {
+			return nil, err
+		}
 	}
+	poolCtx, cancel := context.WithCancel(ctx)
 	pool := ClientPool{
-		space:        *settings.Space,
-		postStart:    settings.PostStart,
-		preStop:      settings.PreStop,
-		statsCh:      statsCh,
-		pool:         connPool,
-		runnerLogger: runnerLogger,
+		ctx:               poolCtx,
+		cancel:            cancel,
+		space:             *settings.Space,
+		user:              *settings.Connection.User,
+		password:          *settings.Connection.Password,
+		healthCheckPeriod: DefaultHealthCheckPeriod,
+		postStart:         settings.PostStart,
+		preStop:           settings.PreStop,
+		statsCh:           statsCh,
+		pool:              connPool,
+		runnerLogger:      runnerLogger,
 	}
 	pool.retry = *settings.Retry
+	pool.retryPolicy, err = NewRetryPolicyFromConfig(settings.RetryRules)
+	if err != nil {
+		return nil, err
+	}
 	pool.concurrency = (*settings.Concurrency) * len(addrs)
 	pool.Sessions = make([]*nebula.Session, pool.concurrency)
+	pool.sessionMu = make([]sync.Mutex, pool.concurrency)
 	pool.requestChs = make([]chan base.ClientRequest, pool.concurrency)
 
 	j := 0
 	for k := 0; k < len(addrs); k++ {
 		for i := 0; i < *settings.Concurrency; i++ {
-			if pool.Sessions[j], err = pool.pool.GetSession(*settings.Connection.User, *settings.Connection.Password); err != nil {
+			if pool.Sessions[j], err = pool.pool.GetSession(pool.user, pool.password); err != nil {
 				return nil, err
 			}
 			pool.requestChs[j] = make(chan base.ClientRequest, *settings.ChannelBufferSize)
@@ -86,6 +141,138 @@ func NewClientPool(settings *config.NebulaClientSettings, statsCh chan<- base.St
 	return &pool, nil
 }
 
+// buildTLSConfig turns a config.NebulaTLSSettings block into a *tls.Config
+// suitable for nebula.NewSslConnectionPool, loading the client certificate
+// pair and CA bundle from disk when configured.
+func buildTLSConfig(settings *config.NebulaTLSSettings) (*tls.Config, error) {
+	tlsConf := &tls.Config{}
+
+	if settings.InsecureSkipVerify != nil {
+		tlsConf.InsecureSkipVerify = *settings.InsecureSkipVerify
+	}
+	if settings.ServerName != nil && *settings.ServerName != "" {
+		tlsConf.ServerName = *settings.ServerName
+	}
+
+	if settings.CertPath != nil && settings.KeyPath != nil && *settings.CertPath != "" && *settings.KeyPath != "" {
+		cert, err := tls.LoadX509KeyPair(*settings.CertPath, *settings.KeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate/key: %s", err.Error())
+		}
+		tlsConf.Certificates = []tls.Certificate{cert}
+	}
+
+	if settings.CAPath != nil && *settings.CAPath != "" {
+		ca, err := os.ReadFile(*settings.CAPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA certificate %s: %s", *settings.CAPath, err.Error())
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("failed to parse CA certificate %s", *settings.CAPath)
+		}
+		tlsConf.RootCAs = pool
+	}
+
+	return tlsConf, nil
+}
+
+// isInvalidSessionError reports whether err/resp indicate the session backing
+// worker i is no longer usable (evicted, expired, or the graphd behind it is
+// gone) and should be replaced rather than retried in place.
+func isInvalidSessionError(err error, resp *nebula.ResultSet) bool {
+	if err != nil {
+		// Any transport-level failure (closed connection, dial error, etc.)
+		// means the session itself can no longer be used.
+		return true
+	}
+	if resp == nil {
+		return false
+	}
+	switch resp.GetErrorCode() {
+	case nebula.ErrorCode_E_SESSION_INVALID, sessionNotFound:
+		return true
+	default:
+		return false
+	}
+}
+
+// SetRetryPolicy overrides the retry classification rules used by all
+// workers. It must be called before Init.
+func (p *ClientPool) SetRetryPolicy(policy *RetryPolicy) {
+	p.retryPolicy = policy
+}
+
+// reconnect discards the (presumably dead) session held by worker i and
+// replaces it with a freshly acquired one, re-running USE <space> and any
+// postStart commands so the new session is in the same state as the old one.
+func (p *ClientPool) reconnect(i int) error {
+	p.sessionMu[i].Lock()
+	defer p.sessionMu[i].Unlock()
+
+	if p.Sessions[i] != nil {
+		p.Sessions[i].Release()
+		p.Sessions[i] = nil
+	}
+
+	session, err := p.pool.GetSession(p.user, p.password)
+	if err != nil {
+		return fmt.Errorf("Client(%d) fails to reconnect, error: %s", i, err.Error())
+	}
+	p.Sessions[i] = session
+
+	if p.postStart != nil && p.postStart.Commands != nil {
+		if err := p.exec(i, *p.postStart.Commands); err != nil {
+			return err
+		}
+	}
+
+	return p.exec(i, fmt.Sprintf("USE `%s`;", p.space))
+}
+
+// ping issues a cheap statement against an otherwise idle session so a
+// session that graphd has silently dropped is detected and replaced before
+// the next real request is routed to it.
+func (p *ClientPool) ping(i int) {
+	if err := p.exec(i, "YIELD 1;"); err != nil {
+		logger.Log.Errorf("Client(%d) health check failed, reconnecting: %s", i, err.Error())
+		if rerr := p.reconnect(i); rerr != nil {
+			logger.Log.Errorf("Client(%d) failed to reconnect after failed health check: %s", i, rerr.Error())
+		}
+	}
+}
+
+// ensureSessionReady runs USE <space> on worker i's session, retrying via
+// reconnect with backoff for up to DefaultRetryMaxElapsedTime. A session
+// that's already stale when the worker starts must not make the worker
+// return permanently: requestChs[i] would then never be drained again, and
+// anything already queued for it (or any caller blocked on ErrCh) would hang
+// forever. Returns false if the session couldn't be made ready in that time.
+func (p *ClientPool) ensureSessionReady(i int) bool {
+	stmt := fmt.Sprintf("USE `%s`;", p.space)
+
+	exp := backoff.NewExponentialBackOff()
+	exp.InitialInterval = DefaultRetryInitialInterval
+	exp.RandomizationFactor = DefaultRetryRandomizationFactor
+	exp.Multiplier = DefaultRetryMultiplier
+	exp.MaxInterval = DefaultRetryMaxInterval
+	exp.MaxElapsedTime = DefaultRetryMaxElapsedTime
+
+	err := backoff.RetryNotify(func() error {
+		if err := p.exec(i, stmt); err != nil {
+			if rerr := p.reconnect(i); rerr != nil {
+				return rerr
+			}
+			return err
+		}
+		return nil
+	}, backoff.WithContext(exp, p.ctx), func(err error, _ time.Duration) {
+		logger.Log.Errorf("Client(%d) not ready yet, retrying: %s", i, err.Error())
+	})
+
+	return err == nil
+}
+
 func (p *ClientPool) getActiveConnIdx() int {
 	for i := range p.Sessions {
 		if p.Sessions[i] != nil {
@@ -99,6 +286,10 @@ func (p *ClientPool) exec(i int, stmt string) error {
 	if len(stmt) == 0 {
 		return nil
 	}
+	if p.Sessions[i] == nil {
+		// A failed reconnect leaves no session behind.
+		return fmt.Errorf("Client(%d) fails to execute commands (%s), error: no session", i, stmt)
+	}
 	resp, err := p.Sessions[i].Execute(stmt)
 	if err != nil {
 		return fmt.Errorf("Client(%d) fails to execute commands (%s), error: %s", i, stmt, err.Error())
@@ -112,6 +303,10 @@ func (p *ClientPool) exec(i int, stmt string) error {
 	return nil
 }
 
+// Close stops the pool from accepting new ClientRequests, cancels in-flight
+// backoff retries, and waits for every worker to exit before releasing
+// sessions and closing the underlying connection pool. The wait is
+// unbounded; callers that need a deadline should use Shutdown instead.
 func (p *ClientPool) Close() {
 	if p.preStop != nil && p.preStop.Commands != nil {
 		if i := p.getActiveConnIdx(); i != -1 {
@@ -121,17 +316,64 @@ func (p *ClientPool) Close() {
 		}
 	}
 
+	p.closeRequestChs()
+	p.cancel()
+	// Canceling the context doesn't synchronously stop an in-flight
+	// Execute/ExecuteWithParameter call, so wait for every worker to
+	// actually exit before releasing the sessions out from under them.
+	p.wg.Wait()
+
 	for i := 0; i < p.concurrency; i++ {
 		if p.Sessions[i] != nil {
 			p.Sessions[i].Release()
 		}
-		if p.requestChs[i] != nil {
-			close(p.requestChs[i])
-		}
 	}
 	p.pool.Close()
 }
 
+// closeRequestChs closes every request channel exactly once, so it's safe to
+// call from both Close and Shutdown regardless of which runs first.
+func (p *ClientPool) closeRequestChs() {
+	p.closeOnce.Do(func() {
+		for _, ch := range p.requestChs {
+			if ch != nil {
+				close(ch)
+			}
+		}
+	})
+}
+
+// Shutdown stops the pool from accepting new ClientRequests and waits for
+// statements already in flight to finish, up to ctx's deadline. Once the
+// deadline passes it cancels the pool context, which ends any backoff wait
+// between retries, and then still waits for a statement a worker is
+// executing to return before releasing sessions; sessions are not released
+// out from under a running statement, so Shutdown can return after ctx's
+// deadline. It returns ctx.Err().
+func (p *ClientPool) Shutdown(ctx context.Context) error {
+	p.closeRequestChs()
+
+	drained := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+	case <-ctx.Done():
+		// The deadline passed with statements still in flight: cancel so
+		// any worker blocked in backoff.RetryNotify returns, then wait for
+		// the workers to actually exit before releasing sessions out from
+		// under them.
+		p.cancel()
+		<-drained
+	}
+
+	p.Close()
+	return ctx.Err()
+}
+
 func (p *ClientPool) Init() error {
 	i := p.getActiveConnIdx()
 	if i == -1 {
@@ -154,7 +396,9 @@ func (p *ClientPool) Init() error {
 	}
 
 	for i := 0; i < p.concurrency; i++ {
+		p.wg.Add(1)
 		go func(i int) {
+			defer p.wg.Done()
 			p.startWorker(i)
 		}(i)
 	}
@@ -162,15 +406,31 @@ func (p *ClientPool) Init() error {
 }
 
 func (p *ClientPool) startWorker(i int) {
-	stmt := fmt.Sprintf("USE `%s`;", p.space)
-	if err := p.exec(i, stmt); err != nil {
-		logger.Log.Error(err.Error())
+	if !p.ensureSessionReady(i) {
+		// The session on worker i never became usable within
+		// DefaultRetryMaxElapsedTime (or the pool context was canceled
+		// first): nothing left to drain into, exit.
 		return
 	}
+
+	ticker := time.NewTicker(p.healthCheckPeriod)
+	defer ticker.Stop()
+
 	for {
-		data, ok := <-p.requestChs[i]
-		if !ok {
-			break
+		var (
+			data base.ClientRequest
+			ok   bool
+		)
+		select {
+		case data, ok = <-p.requestChs[i]:
+			if !ok {
+				return
+			}
+		case <-ticker.C:
+			p.ping(i)
+			continue
+		case <-p.ctx.Done():
+			return
 		}
 
 		if data.Stmt == base.STAT_FILEDONE {
@@ -180,62 +440,84 @@ func (p *ClientPool) startWorker(i int) {
 
 		now := time.Now()
 
+		policy := p.retryPolicy
+
 		exp := backoff.NewExponentialBackOff()
-		exp.InitialInterval = DefaultRetryInitialInterval
+		exp.InitialInterval = policy.InitialInterval
 		exp.RandomizationFactor = DefaultRetryRandomizationFactor
 		exp.Multiplier = DefaultRetryMultiplier
-		exp.MaxInterval = DefaultRetryMaxInterval
-		exp.MaxElapsedTime = DefaultRetryMaxElapsedTime
+		exp.MaxInterval = policy.MaxInterval
+		exp.MaxElapsedTime = policy.MaxElapsed
 
 		var (
-			err   error
-			resp  *nebula.ResultSet
-			retry = p.retry
+			err           error
+			resp          *nebula.ResultSet
+			retry         = p.retry
+			ruleTimingSet bool
 		)
 
-		// There are three cases of retry
-		// * Case 1: retry no more
-		// * Case 2. retry as much as possible
-		// * Case 3: retry with limit times
-		_ = backoff.Retry(func() error {
-			resp, err = p.Sessions[i].Execute(data.Stmt)
+		// Each failed execution is classified by the retry policy into one
+		// of three actions:
+		// * permanent: stop the retry
+		// * infinite:  retry as much as possible
+		// * bounded:   retry with limit times
+		_ = backoff.RetryNotify(func() error {
+			if p.Sessions[i] == nil {
+				// An earlier reconnect failed and left no session: try again.
+				if rerr := p.reconnect(i); rerr != nil {
+					return rerr
+				}
+			}
+			if len(data.Params) > 0 {
+				resp, err = p.Sessions[i].ExecuteWithParameter(data.Stmt, data.Params)
+			} else {
+				resp, err = p.Sessions[i].Execute(data.Stmt)
+			}
 			if err == nil && resp.IsSucceed() {
 				return nil
 			}
+
+			if isInvalidSessionError(err, resp) {
+				if rerr := p.reconnect(i); rerr != nil {
+					// The replacement session couldn't be established either;
+					// keep retrying, the backoff loop will eventually give up.
+					return rerr
+				}
+				return fmt.Errorf("session on client(%d) was invalid and has been replaced, retrying", i)
+			}
+
 			retryErr := err
 			if resp != nil {
 				errorCode, errorMsg := resp.GetErrorCode(), resp.GetErrorMsg()
 				retryErr = fmt.Errorf("%d:%s", errorCode, errorMsg)
 
-				// Case 1: retry no more
-				var isPermanentError = true
-				switch errorCode {
-				case nebula.ErrorCode_E_SYNTAX_ERROR:
-				case nebula.ErrorCode_E_SEMANTIC_ERROR:
-				default:
-					isPermanentError = false
-				}
-				if isPermanentError {
-					// stop the retry
-					return backoff.Permanent(retryErr)
+				action, rule := policy.classify(errorCode, errorMsg)
+				if !ruleTimingSet {
+					// Only apply the matched rule's backoff overrides once:
+					// re-applying them on every failure would reset exp's
+					// currentInterval back to InitialInterval each time and
+					// defeat exponential growth.
+					ruleTimingSet = true
+					applyRuleTiming(exp, rule)
 				}
 
-				// Case 2. retry as much as possible
-				// TODO: compare with E_RAFT_BUFFER_OVERFLOW
-				// Can not get the E_RAFT_BUFFER_OVERFLOW inside storage now.
-				if strings.Contains(errorMsg, "raft buffer is full") {
+				switch action {
+				case RetryPermanent:
+					return backoff.Permanent(retryErr)
+				case RetryInfinite:
 					retry = p.retry
 					return retryErr
 				}
 			}
-			// Case 3: retry with limit times
+			// RetryBounded (or a transport error with no response): retry
+			// with limit times.
 			if retry <= 0 {
 				// stop the retry
 				return backoff.Permanent(retryErr)
 			}
 			retry--
 			return retryErr
-		}, exp)
+		}, backoff.WithContext(exp, p.ctx), nil)
 
 		if err != nil {
 			err = fmt.Errorf("Client %d fail to execute: %s, Error: %s", i, data.Stmt, err.Error())
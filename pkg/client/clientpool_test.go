@@ -0,0 +1,45 @@
+package client
+
+import (
+	"fmt"
+	"testing"
+
+	nebula "github.com/vesoft-inc/nebula-go/v3"
+	ngraph "github.com/vesoft-inc/nebula-go/v3/nebula"
+	graph "github.com/vesoft-inc/nebula-go/v3/nebula/graph"
+)
+
+func resultSetWithCode(t *testing.T, code nebula.ErrorCode) *nebula.ResultSet {
+	t.Helper()
+	resp := graph.NewExecutionResponse()
+	resp.ErrorCode = ngraph.ErrorCode(code)
+	rs, err := nebula.GenResultSet(resp)
+	if err != nil {
+		t.Fatalf("GenResultSet: %v", err)
+	}
+	return rs
+}
+
+func TestIsInvalidSessionError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		resp *nebula.ResultSet
+		want bool
+	}{
+		{name: "transport error", err: fmt.Errorf("connection closed"), want: true},
+		{name: "session invalid", resp: resultSetWithCode(t, nebula.ErrorCode_E_SESSION_INVALID), want: true},
+		{name: "session not found", resp: resultSetWithCode(t, sessionNotFound), want: true},
+		{name: "syntax error", resp: resultSetWithCode(t, nebula.ErrorCode_E_SYNTAX_ERROR), want: false},
+		{name: "no response, no error", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := isInvalidSessionError(tt.err, tt.resp)
+			if got != tt.want {
+				t.Fatalf("isInvalidSessionError(%v, %v) = %v, want %v", tt.err, tt.resp, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	nebula "github.com/vesoft-inc/nebula-go/v3"
+	"github.com/vesoft-inc/nebula-importer/v3/pkg/base"
+)
+
+func TestCloseWaitsForWorkers(t *testing.T) {
+	p := &ClientPool{}
+	ctx, cancel := context.WithCancel(context.Background())
+	p.ctx = ctx
+	p.cancel = cancel
+	p.concurrency = 1
+	p.Sessions = make([]*nebula.Session, 1)
+	p.sessionMu = make([]sync.Mutex, 1)
+	p.requestChs = make([]chan base.ClientRequest, 0)
+	p.pool = &nebula.ConnectionPool{}
+
+	var released int32
+	workerDone := make(chan struct{})
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		<-p.ctx.Done()
+		// Simulate a worker that's still mid-RPC for a moment after the
+		// context is canceled: Close must not release sessions until this
+		// goroutine actually returns.
+		time.Sleep(20 * time.Millisecond)
+		released = 1
+		close(workerDone)
+	}()
+
+	p.Close()
+
+	select {
+	case <-workerDone:
+	default:
+		t.Fatal("Close returned before the worker goroutine finished")
+	}
+	if released != 1 {
+		t.Fatal("Close returned before the worker finished its simulated in-flight work")
+	}
+}
+
+func TestShutdownCancelsOnDeadline(t *testing.T) {
+	p := &ClientPool{}
+	ctx, cancel := context.WithCancel(context.Background())
+	p.ctx = ctx
+	p.cancel = cancel
+	p.concurrency = 1
+	p.Sessions = make([]*nebula.Session, 1)
+	p.sessionMu = make([]sync.Mutex, 1)
+	p.requestChs = make([]chan base.ClientRequest, 0)
+	p.pool = &nebula.ConnectionPool{}
+
+	blocked := make(chan struct{})
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		close(blocked)
+		<-p.ctx.Done()
+	}()
+	<-blocked
+
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer shutdownCancel()
+
+	err := p.Shutdown(shutdownCtx)
+	if err == nil {
+		t.Fatal("Shutdown returned nil error, want the deadline-exceeded error")
+	}
+	if p.ctx.Err() == nil {
+		t.Fatal("Shutdown did not cancel the pool context after its deadline passed")
+	}
+}
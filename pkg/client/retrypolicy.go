@@ -0,0 +1,173 @@
+package client
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	nebula "github.com/vesoft-inc/nebula-go/v3"
+	"github.com/vesoft-inc/nebula-importer/v3/pkg/config"
+)
+
+// RetryAction classifies how a failed statement should be handled once its
+// error code (or message) matches a RetryRule.
+type RetryAction string
+
+const (
+	// RetryPermanent stops retrying and surfaces the error immediately.
+	RetryPermanent RetryAction = "permanent"
+	// RetryBounded retries up to ClientPool.retry times before giving up.
+	RetryBounded RetryAction = "bounded"
+	// RetryInfinite keeps retrying with backoff, with no limit on the number
+	// of attempts, until the statement succeeds, the backoff's MaxElapsed
+	// (the rule's, else the policy's) runs out, or the pool is shut down.
+	RetryInfinite RetryAction = "infinite"
+)
+
+// RetryRule maps a Nebula error code, or a regex over the error message, to
+// a RetryAction. ErrorCode rules are checked before the MessagePattern
+// fallback. Per-rule backoff parameters override the policy defaults when
+// set (zero value means "use the policy default").
+type RetryRule struct {
+	ErrorCode       nebula.ErrorCode
+	MessagePattern  *regexp.Regexp
+	Action          RetryAction
+	InitialInterval time.Duration
+	MaxInterval     time.Duration
+	MaxElapsed      time.Duration
+}
+
+// RetryPolicy holds the ordered set of rules consulted after each failed
+// execution, plus the backoff defaults used when a matching rule (or the
+// fallback) doesn't override them.
+type RetryPolicy struct {
+	Rules           []RetryRule
+	InitialInterval time.Duration
+	MaxInterval     time.Duration
+	MaxElapsed      time.Duration
+}
+
+// DefaultRetryPolicy reproduces the behavior ClientPool used before retry
+// classification became configurable: syntax/semantic errors are permanent,
+// a raft-buffer-full storage error retries forever, and everything else is
+// bounded by ClientPool.retry.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		InitialInterval: DefaultRetryInitialInterval,
+		MaxInterval:     DefaultRetryMaxInterval,
+		MaxElapsed:      DefaultRetryMaxElapsedTime,
+		Rules: []RetryRule{
+			{ErrorCode: nebula.ErrorCode_E_SYNTAX_ERROR, Action: RetryPermanent},
+			{ErrorCode: nebula.ErrorCode_E_SEMANTIC_ERROR, Action: RetryPermanent},
+			{MessagePattern: regexp.MustCompile("raft buffer is full"), Action: RetryInfinite},
+		},
+	}
+}
+
+// NewRetryPolicyFromConfig builds the RetryPolicy a ClientPool should use
+// from the operator-supplied rules in settings.RetryRules, prepended ahead of
+// DefaultRetryPolicy's rules so a configured rule always takes priority over
+// the built-in defaults for the same error code or message pattern. This is
+// how an operator marks e.g. E_LEADER_CHANGED as retry-forever without
+// recompiling: add a rule with that ErrorCode and action "infinite" to the
+// importer's config file.
+func NewRetryPolicyFromConfig(settings []config.NebulaRetryRule) (*RetryPolicy, error) {
+	policy := DefaultRetryPolicy()
+	rules := make([]RetryRule, 0, len(settings))
+
+	for idx, rule := range settings {
+		action, err := parseRetryAction(rule.Action)
+		if err != nil {
+			return nil, fmt.Errorf("retryRules[%d]: %w", idx, err)
+		}
+
+		converted := RetryRule{Action: action}
+
+		if rule.ErrorCode != nil {
+			converted.ErrorCode = nebula.ErrorCode(*rule.ErrorCode)
+		}
+		if rule.MessagePattern != nil && *rule.MessagePattern != "" {
+			pattern, err := regexp.Compile(*rule.MessagePattern)
+			if err != nil {
+				return nil, fmt.Errorf("retryRules[%d]: invalid messagePattern: %w", idx, err)
+			}
+			converted.MessagePattern = pattern
+		}
+		if converted.ErrorCode == 0 && converted.MessagePattern == nil {
+			return nil, fmt.Errorf("retryRules[%d]: must set errorCode or messagePattern", idx)
+		}
+
+		if converted.InitialInterval, err = parseRetryDuration(rule.InitialInterval); err != nil {
+			return nil, fmt.Errorf("retryRules[%d]: invalid initialInterval: %w", idx, err)
+		}
+		if converted.MaxInterval, err = parseRetryDuration(rule.MaxInterval); err != nil {
+			return nil, fmt.Errorf("retryRules[%d]: invalid maxInterval: %w", idx, err)
+		}
+		if converted.MaxElapsed, err = parseRetryDuration(rule.MaxElapsed); err != nil {
+			return nil, fmt.Errorf("retryRules[%d]: invalid maxElapsed: %w", idx, err)
+		}
+
+		rules = append(rules, converted)
+	}
+
+	policy.Rules = append(rules, policy.Rules...)
+	return policy, nil
+}
+
+func parseRetryAction(action *string) (RetryAction, error) {
+	if action == nil {
+		return "", fmt.Errorf("action is required")
+	}
+	switch RetryAction(*action) {
+	case RetryPermanent, RetryBounded, RetryInfinite:
+		return RetryAction(*action), nil
+	default:
+		return "", fmt.Errorf("unknown action %q", *action)
+	}
+}
+
+func parseRetryDuration(s *string) (time.Duration, error) {
+	if s == nil || *s == "" {
+		return 0, nil
+	}
+	return time.ParseDuration(*s)
+}
+
+// classify returns the RetryAction for the given error code/message, along
+// with the RetryRule that produced it so the caller can apply that rule's
+// backoff overrides. Returns (RetryBounded, nil) when no rule matches.
+func (p *RetryPolicy) classify(errorCode nebula.ErrorCode, errorMsg string) (RetryAction, *RetryRule) {
+	for i := range p.Rules {
+		rule := &p.Rules[i]
+		if rule.ErrorCode != 0 && rule.ErrorCode == errorCode {
+			return rule.Action, rule
+		}
+	}
+	for i := range p.Rules {
+		rule := &p.Rules[i]
+		if rule.MessagePattern != nil && rule.MessagePattern.MatchString(errorMsg) {
+			return rule.Action, rule
+		}
+	}
+	return RetryBounded, nil
+}
+
+// applyRuleTiming overrides exp's backoff parameters with any non-zero
+// per-rule values in rule, then resets exp so the override takes effect
+// starting from the next backoff. A nil rule (no rule matched) is a no-op.
+func applyRuleTiming(exp *backoff.ExponentialBackOff, rule *RetryRule) {
+	if rule == nil {
+		return
+	}
+	if rule.InitialInterval > 0 {
+		exp.InitialInterval = rule.InitialInterval
+	}
+	if rule.MaxInterval > 0 {
+		exp.MaxInterval = rule.MaxInterval
+	}
+	if rule.MaxElapsed > 0 {
+		exp.MaxElapsedTime = rule.MaxElapsed
+	}
+	exp.Reset()
+}
@@ -0,0 +1,180 @@
+package client
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	nebula "github.com/vesoft-inc/nebula-go/v3"
+	"github.com/vesoft-inc/nebula-importer/v3/pkg/config"
+)
+
+func i32Ptr(i int32) *int32   { return &i }
+func strPtr(s string) *string { return &s }
+
+// leaderChanged is E_LEADER_CHANGED's numeric error code (-4), used here as
+// a stand-in for an error code the nebula-go client doesn't expose as a
+// named constant in its top-level package.
+const leaderChanged = int32(-4)
+
+func TestNewRetryPolicyFromConfig(t *testing.T) {
+	action := "infinite"
+	interval := "50ms"
+
+	policy, err := NewRetryPolicyFromConfig([]config.NebulaRetryRule{
+		{ErrorCode: i32Ptr(leaderChanged), Action: strPtr(action), InitialInterval: strPtr(interval)},
+	})
+	if err != nil {
+		t.Fatalf("NewRetryPolicyFromConfig returned error: %v", err)
+	}
+
+	got, _ := policy.classify(nebula.ErrorCode(leaderChanged), "")
+	if got != RetryInfinite {
+		t.Fatalf("classify(E_LEADER_CHANGED) = %v, want %v", got, RetryInfinite)
+	}
+
+	// Built-in defaults are still present and still apply to codes the
+	// operator didn't override.
+	got, _ = policy.classify(nebula.ErrorCode_E_SYNTAX_ERROR, "")
+	if got != RetryPermanent {
+		t.Fatalf("classify(E_SYNTAX_ERROR) = %v, want %v", got, RetryPermanent)
+	}
+}
+
+func TestNewRetryPolicyFromConfigOverridesDefault(t *testing.T) {
+	code := int32(nebula.ErrorCode_E_SYNTAX_ERROR)
+	action := "bounded"
+
+	policy, err := NewRetryPolicyFromConfig([]config.NebulaRetryRule{
+		{ErrorCode: i32Ptr(code), Action: strPtr(action)},
+	})
+	if err != nil {
+		t.Fatalf("NewRetryPolicyFromConfig returned error: %v", err)
+	}
+
+	got, _ := policy.classify(nebula.ErrorCode_E_SYNTAX_ERROR, "")
+	if got != RetryBounded {
+		t.Fatalf("classify(E_SYNTAX_ERROR) = %v, want %v (operator rule should win)", got, RetryBounded)
+	}
+}
+
+func TestNewRetryPolicyFromConfigRejectsUnknownAction(t *testing.T) {
+	action := "sometimes"
+	_, err := NewRetryPolicyFromConfig([]config.NebulaRetryRule{
+		{ErrorCode: i32Ptr(1), Action: strPtr(action)},
+	})
+	if err == nil {
+		t.Fatal("expected error for unknown action, got nil")
+	}
+}
+
+func TestNewRetryPolicyFromConfigRejectsMissingMatcher(t *testing.T) {
+	action := "permanent"
+	_, err := NewRetryPolicyFromConfig([]config.NebulaRetryRule{
+		{Action: strPtr(action)},
+	})
+	if err == nil {
+		t.Fatal("expected error when neither errorCode nor messagePattern is set, got nil")
+	}
+}
+
+func TestNewRetryPolicyFromConfigMessagePattern(t *testing.T) {
+	action := "infinite"
+	pattern := "disk full"
+
+	policy, err := NewRetryPolicyFromConfig([]config.NebulaRetryRule{
+		{MessagePattern: strPtr(pattern), Action: strPtr(action)},
+	})
+	if err != nil {
+		t.Fatalf("NewRetryPolicyFromConfig returned error: %v", err)
+	}
+
+	got, _ := policy.classify(0, "storage: disk full on host")
+	if got != RetryInfinite {
+		t.Fatalf("classify(message match) = %v, want %v", got, RetryInfinite)
+	}
+}
+
+func TestParseRetryDuration(t *testing.T) {
+	d, err := parseRetryDuration(nil)
+	if err != nil || d != 0 {
+		t.Fatalf("parseRetryDuration(nil) = %v, %v, want 0, nil", d, err)
+	}
+
+	s := "1500ms"
+	d, err = parseRetryDuration(&s)
+	if err != nil || d != 1500*time.Millisecond {
+		t.Fatalf("parseRetryDuration(%q) = %v, %v, want %v, nil", s, d, err, 1500*time.Millisecond)
+	}
+}
+
+// TestClassifyRuleTimingOverridesBackoff drives an actual failing
+// backoff.Retry cycle using the timing applyRuleTiming copies out of the
+// matched RetryRule, the same sequence startWorker runs: classify the
+// error, apply the rule's overrides to a fresh exponential backoff, then
+// retry. It asserts the rule's (tiny) MaxElapsed bounds the retry loop
+// rather than the policy's (much larger) default, proving the override
+// actually reaches the backoff instance and isn't just computed and
+// discarded.
+func TestClassifyRuleTimingOverridesBackoff(t *testing.T) {
+	action := "infinite"
+	initial := "1ms"
+	maxInterval := "2ms"
+	maxElapsed := "20ms"
+
+	policy, err := NewRetryPolicyFromConfig([]config.NebulaRetryRule{
+		{
+			ErrorCode:       i32Ptr(leaderChanged),
+			Action:          strPtr(action),
+			InitialInterval: strPtr(initial),
+			MaxInterval:     strPtr(maxInterval),
+			MaxElapsed:      strPtr(maxElapsed),
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewRetryPolicyFromConfig returned error: %v", err)
+	}
+
+	exp := backoff.NewExponentialBackOff()
+	exp.InitialInterval = policy.InitialInterval
+	exp.MaxInterval = policy.MaxInterval
+	exp.MaxElapsedTime = policy.MaxElapsed
+	exp.Reset()
+
+	_, rule := policy.classify(nebula.ErrorCode(leaderChanged), "")
+	if rule == nil {
+		t.Fatal("classify returned no matched rule, want the configured leaderChanged rule")
+	}
+	applyRuleTiming(exp, rule)
+
+	if exp.InitialInterval != 1*time.Millisecond {
+		t.Fatalf("exp.InitialInterval = %v, want 1ms", exp.InitialInterval)
+	}
+	if exp.MaxInterval != 2*time.Millisecond {
+		t.Fatalf("exp.MaxInterval = %v, want 2ms", exp.MaxInterval)
+	}
+	if exp.MaxElapsedTime != 20*time.Millisecond {
+		t.Fatalf("exp.MaxElapsedTime = %v, want 20ms", exp.MaxElapsedTime)
+	}
+
+	attempts := 0
+	start := time.Now()
+	retryErr := backoff.Retry(func() error {
+		attempts++
+		return fmt.Errorf("still failing")
+	}, exp)
+	elapsed := time.Since(start)
+
+	if retryErr == nil {
+		t.Fatal("expected backoff.Retry to give up once MaxElapsedTime passed, got nil error")
+	}
+	if attempts < 2 {
+		t.Fatalf("attempts = %d, want at least 2 (the rule's 20ms MaxElapsed should allow more than one try)", attempts)
+	}
+	// The policy default MaxElapsed is an hour; if the rule's override
+	// weren't applied, this loop would still be running.
+	if elapsed > 500*time.Millisecond {
+		t.Fatalf("backoff.Retry ran for %v, want it bounded by the rule's 20ms MaxElapsed", elapsed)
+	}
+}
@@ -0,0 +1,112 @@
+package client
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/vesoft-inc/nebula-importer/v3/pkg/config"
+)
+
+// generateSelfSignedCertForTest returns a freshly generated self-signed
+// certificate and its private key, both PEM-encoded, for exercising
+// buildTLSConfig's file-loading paths without a real CA.
+func generateSelfSignedCertForTest(t *testing.T) (certPEM, keyPEM []byte) {
+	t.Helper()
+
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "nebula-importer-test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		IsCA:         true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &priv.PublicKey, priv)
+	if err != nil {
+		t.Fatalf("create certificate: %v", err)
+	}
+	certPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+
+	keyDER, err := x509.MarshalECPrivateKey(priv)
+	if err != nil {
+		t.Fatalf("marshal key: %v", err)
+	}
+	keyPEM = pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+
+	return certPEM, keyPEM
+}
+
+func TestBuildTLSConfigPlainFields(t *testing.T) {
+	serverName := "graphd.example.com"
+	insecure := true
+	cfg, err := buildTLSConfig(&config.NebulaTLSSettings{
+		ServerName:         &serverName,
+		InsecureSkipVerify: &insecure,
+	})
+	if err != nil {
+		t.Fatalf("buildTLSConfig returned error: %v", err)
+	}
+	if cfg.ServerName != serverName {
+		t.Errorf("ServerName = %q, want %q", cfg.ServerName, serverName)
+	}
+	if !cfg.InsecureSkipVerify {
+		t.Errorf("InsecureSkipVerify = false, want true")
+	}
+}
+
+func TestBuildTLSConfigCertAndCA(t *testing.T) {
+	dir := t.TempDir()
+	certPath := filepath.Join(dir, "cert.pem")
+	keyPath := filepath.Join(dir, "key.pem")
+	caPath := filepath.Join(dir, "ca.pem")
+
+	cert, key := generateSelfSignedCertForTest(t)
+	if err := os.WriteFile(certPath, cert, 0o600); err != nil {
+		t.Fatalf("write cert: %v", err)
+	}
+	if err := os.WriteFile(keyPath, key, 0o600); err != nil {
+		t.Fatalf("write key: %v", err)
+	}
+	if err := os.WriteFile(caPath, cert, 0o600); err != nil {
+		t.Fatalf("write ca: %v", err)
+	}
+
+	cfg, err := buildTLSConfig(&config.NebulaTLSSettings{
+		CertPath: &certPath,
+		KeyPath:  &keyPath,
+		CAPath:   &caPath,
+	})
+	if err != nil {
+		t.Fatalf("buildTLSConfig returned error: %v", err)
+	}
+	if len(cfg.Certificates) != 1 {
+		t.Fatalf("Certificates = %d, want 1", len(cfg.Certificates))
+	}
+	if cfg.RootCAs == nil {
+		t.Fatal("RootCAs = nil, want non-nil")
+	}
+}
+
+func TestBuildTLSConfigBadCertPath(t *testing.T) {
+	missing := "/does/not/exist.pem"
+	_, err := buildTLSConfig(&config.NebulaTLSSettings{
+		CertPath: &missing,
+		KeyPath:  &missing,
+	})
+	if err == nil {
+		t.Fatal("expected error for missing cert/key files, got nil")
+	}
+}
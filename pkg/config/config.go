@@ -0,0 +1,57 @@
+package config
+
+// NebulaClientConnection describes how to reach and authenticate against the
+// Nebula cluster a ClientPool's sessions connect to.
+type NebulaClientConnection struct {
+	User     *string            `json:"user" yaml:"user"`
+	Password *string            `json:"password" yaml:"password"`
+	Address  *string            `json:"address" yaml:"address"`
+	TLS      *NebulaTLSSettings `json:"tls" yaml:"tls"`
+}
+
+// NebulaTLSSettings configures TLS/mTLS for the connections in a ClientPool.
+// When Enabled is unset or false, the pool connects in plaintext.
+type NebulaTLSSettings struct {
+	Enabled            *bool   `json:"enabled" yaml:"enabled"`
+	CAPath             *string `json:"caPath" yaml:"caPath"`
+	CertPath           *string `json:"certPath" yaml:"certPath"`
+	KeyPath            *string `json:"keyPath" yaml:"keyPath"`
+	ServerName         *string `json:"serverName" yaml:"serverName"`
+	InsecureSkipVerify *bool   `json:"insecureSkipVerify" yaml:"insecureSkipVerify"`
+}
+
+type NebulaPostStart struct {
+	Commands    *string `json:"commands" yaml:"commands"`
+	AfterPeriod *string `json:"afterPeriod" yaml:"afterPeriod"`
+}
+
+type NebulaPreStop struct {
+	Commands *string `json:"commands" yaml:"commands"`
+}
+
+type NebulaClientSettings struct {
+	Retry             *int                    `json:"retry" yaml:"retry"`
+	Concurrency       *int                    `json:"concurrency" yaml:"concurrency"`
+	ChannelBufferSize *int                    `json:"channelBufferSize" yaml:"channelBufferSize"`
+	Space             *string                 `json:"space" yaml:"space"`
+	Connection        *NebulaClientConnection `json:"connection" yaml:"connection"`
+	PostStart         *NebulaPostStart        `json:"postStart" yaml:"postStart"`
+	PreStop           *NebulaPreStop          `json:"preStop" yaml:"preStop"`
+	RetryRules        []NebulaRetryRule       `json:"retryRules" yaml:"retryRules"`
+}
+
+// NebulaRetryRule lets an operator mark a specific error code (or a regex
+// over the error message) as permanently failing, retry-forever, or
+// retry-bounded, without rebuilding the importer. ErrorCode rules are
+// checked before MessagePattern rules. InitialInterval/MaxInterval/
+// MaxElapsed are Go duration strings (e.g. "500ms", "2m") that override the
+// pool's backoff defaults for statements this rule matches; leave unset to
+// use the defaults.
+type NebulaRetryRule struct {
+	ErrorCode       *int32  `json:"errorCode" yaml:"errorCode"`
+	MessagePattern  *string `json:"messagePattern" yaml:"messagePattern"`
+	Action          *string `json:"action" yaml:"action"`
+	InitialInterval *string `json:"initialInterval" yaml:"initialInterval"`
+	MaxInterval     *string `json:"maxInterval" yaml:"maxInterval"`
+	MaxElapsed      *string `json:"maxElapsed" yaml:"maxElapsed"`
+}
@@ -0,0 +1,149 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Prop binds one CSV column to a tag/edge property. Parameter, when set,
+// names the $-bound parameter the property is sent under, so a whole row
+// can be executed with Session.ExecuteWithParameter against a single
+// reusable template statement instead of a value rendered into the
+// statement string per row. Columns with no Parameter binding (e.g. the
+// VID/src/dst columns) are still sent as bound parameters, not rendered
+// literally: the caller supplies their value under the template's own
+// parameter name via BuildVertexParams/BuildEdgeParams instead of going
+// through a Prop at all.
+type Prop struct {
+	Name      *string `json:"name" yaml:"name"`
+	Type      *string `json:"type" yaml:"type"`
+	Index     *int    `json:"index" yaml:"index"`
+	Parameter *string `json:"parameter" yaml:"parameter"`
+}
+
+// Tag is a set of Props inserted together with one parameterized template
+// statement, reused for every row belonging to that tag.
+type Tag struct {
+	Name  *string `json:"name" yaml:"name"`
+	Props []*Prop `json:"props" yaml:"props"`
+}
+
+// Edge is the edge-insertion counterpart of Tag.
+type Edge struct {
+	Name  *string `json:"name" yaml:"name"`
+	Props []*Prop `json:"props" yaml:"props"`
+}
+
+// InsertVertexTemplate renders the reusable
+// "INSERT VERTEX tag(p1,p2) VALUES $vid:($p1,$p2)" statement for t. Build
+// this once per tag and pair it with BuildVertexParams per row.
+func (t *Tag) InsertVertexTemplate(vidParam string) string {
+	names, params := propNamesAndParams(t.Props)
+	return fmt.Sprintf("INSERT VERTEX %s(%s) VALUES $%s:(%s)",
+		*t.Name, strings.Join(names, ","), vidParam, strings.Join(params, ","))
+}
+
+// InsertEdgeTemplate renders the reusable
+// "INSERT EDGE edge(p1,p2) VALUES $src->$dst:($p1,$p2)" statement for e.
+func (e *Edge) InsertEdgeTemplate(srcParam, dstParam string) string {
+	names, params := propNamesAndParams(e.Props)
+	return fmt.Sprintf("INSERT EDGE %s(%s) VALUES $%s->$%s:(%s)",
+		*e.Name, strings.Join(names, ","), srcParam, dstParam, strings.Join(params, ","))
+}
+
+func propNamesAndParams(props []*Prop) (names, params []string) {
+	for _, p := range props {
+		names = append(names, *p.Name)
+		params = append(params, "$"+*p.Parameter)
+	}
+	return names, params
+}
+
+// BuildParams converts row into the typed Go values named by props' Parameter
+// bindings, ready to hand to base.ClientRequest.Params. Props with no
+// Parameter binding are skipped; it does not bind the VID/src/dst value a
+// template references outside of props — use BuildVertexParams or
+// BuildEdgeParams for that.
+func BuildParams(props []*Prop, row []string) (map[string]interface{}, error) {
+	params := make(map[string]interface{}, len(props))
+	for _, p := range props {
+		if p.Parameter == nil || *p.Parameter == "" {
+			continue
+		}
+		if p.Index == nil || *p.Index < 0 || *p.Index >= len(row) {
+			return nil, fmt.Errorf("prop %q: index out of range for row of length %d", propName(p), len(row))
+		}
+		value, err := convertPropValue(row[*p.Index], p.Type)
+		if err != nil {
+			return nil, fmt.Errorf("prop %q: %w", propName(p), err)
+		}
+		params[*p.Parameter] = value
+	}
+	return params, nil
+}
+
+// BuildVertexParams builds the full parameter map for a statement rendered
+// by InsertVertexTemplate(vidParam): props' values plus vid bound under
+// vidParam itself, exactly as the template references it ($vidParam).
+func BuildVertexParams(vidParam, vid string, props []*Prop, row []string) (map[string]interface{}, error) {
+	params, err := BuildParams(props, row)
+	if err != nil {
+		return nil, err
+	}
+	params[vidParam] = vid
+	return params, nil
+}
+
+// BuildEdgeParams builds the full parameter map for a statement rendered by
+// InsertEdgeTemplate(srcParam, dstParam): props' values plus src/dst bound
+// under their own parameter names.
+func BuildEdgeParams(srcParam, src, dstParam, dst string, props []*Prop, row []string) (map[string]interface{}, error) {
+	params, err := BuildParams(props, row)
+	if err != nil {
+		return nil, err
+	}
+	params[srcParam] = src
+	params[dstParam] = dst
+	return params, nil
+}
+
+func propName(p *Prop) string {
+	if p.Name != nil {
+		return *p.Name
+	}
+	return ""
+}
+
+// convertPropValue converts a single CSV cell to the Go value Nebula's
+// parameter binding expects for typ: bool/int64/float64/string, or list/map
+// decoded from JSON-formatted CSV cells (e.g. `[1,2,3]`, `{"k":"v"}`).
+func convertPropValue(raw string, typ *string) (interface{}, error) {
+	t := "string"
+	if typ != nil {
+		t = strings.ToLower(*typ)
+	}
+	switch t {
+	case "bool":
+		return strconv.ParseBool(raw)
+	case "int", "int64":
+		return strconv.ParseInt(raw, 10, 64)
+	case "float", "double":
+		return strconv.ParseFloat(raw, 64)
+	case "list":
+		var v []interface{}
+		if err := json.Unmarshal([]byte(raw), &v); err != nil {
+			return nil, fmt.Errorf("invalid list value %q: %w", raw, err)
+		}
+		return v, nil
+	case "map":
+		var v map[string]interface{}
+		if err := json.Unmarshal([]byte(raw), &v); err != nil {
+			return nil, fmt.Errorf("invalid map value %q: %w", raw, err)
+		}
+		return v, nil
+	default:
+		return raw, nil
+	}
+}
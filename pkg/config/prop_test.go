@@ -0,0 +1,109 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func strPtr(s string) *string { return &s }
+func intPtr(i int) *int       { return &i }
+
+func TestBuildParams(t *testing.T) {
+	props := []*Prop{
+		{Name: strPtr("name"), Type: strPtr("string"), Index: intPtr(0), Parameter: strPtr("name")},
+		{Name: strPtr("age"), Type: strPtr("int"), Index: intPtr(1), Parameter: strPtr("age")},
+		{Name: strPtr("score"), Type: strPtr("double"), Index: intPtr(2), Parameter: strPtr("score")},
+		{Name: strPtr("active"), Type: strPtr("bool"), Index: intPtr(3), Parameter: strPtr("active")},
+		{Name: strPtr("tags"), Type: strPtr("list"), Index: intPtr(4), Parameter: strPtr("tags")},
+		{Name: strPtr("vid"), Type: strPtr("string"), Index: intPtr(5)},
+	}
+	row := []string{"bob", "30", "9.5", "true", `["a","b"]`, "v1"}
+
+	params, err := BuildParams(props, row)
+	if err != nil {
+		t.Fatalf("BuildParams returned error: %v", err)
+	}
+
+	want := map[string]interface{}{
+		"name":   "bob",
+		"age":    int64(30),
+		"score":  9.5,
+		"active": true,
+		"tags":   []interface{}{"a", "b"},
+	}
+	if !reflect.DeepEqual(params, want) {
+		t.Fatalf("BuildParams = %#v, want %#v", params, want)
+	}
+}
+
+func TestBuildParamsIndexOutOfRange(t *testing.T) {
+	props := []*Prop{
+		{Name: strPtr("name"), Type: strPtr("string"), Index: intPtr(5), Parameter: strPtr("name")},
+	}
+	if _, err := BuildParams(props, []string{"bob"}); err == nil {
+		t.Fatal("expected error for out-of-range index, got nil")
+	}
+}
+
+func TestBuildVertexParams(t *testing.T) {
+	props := []*Prop{
+		{Name: strPtr("name"), Type: strPtr("string"), Index: intPtr(0), Parameter: strPtr("name")},
+	}
+	row := []string{"bob"}
+
+	params, err := BuildVertexParams("vid", "v1", props, row)
+	if err != nil {
+		t.Fatalf("BuildVertexParams returned error: %v", err)
+	}
+
+	want := map[string]interface{}{"name": "bob", "vid": "v1"}
+	if !reflect.DeepEqual(params, want) {
+		t.Fatalf("BuildVertexParams = %#v, want %#v", params, want)
+	}
+}
+
+func TestBuildEdgeParams(t *testing.T) {
+	props := []*Prop{
+		{Name: strPtr("since"), Type: strPtr("string"), Index: intPtr(0), Parameter: strPtr("since")},
+	}
+	row := []string{"2020"}
+
+	params, err := BuildEdgeParams("src", "v1", "dst", "v2", props, row)
+	if err != nil {
+		t.Fatalf("BuildEdgeParams returned error: %v", err)
+	}
+
+	want := map[string]interface{}{"since": "2020", "src": "v1", "dst": "v2"}
+	if !reflect.DeepEqual(params, want) {
+		t.Fatalf("BuildEdgeParams = %#v, want %#v", params, want)
+	}
+}
+
+func TestInsertVertexTemplate(t *testing.T) {
+	tag := &Tag{
+		Name: strPtr("person"),
+		Props: []*Prop{
+			{Name: strPtr("name"), Parameter: strPtr("name")},
+			{Name: strPtr("age"), Parameter: strPtr("age")},
+		},
+	}
+	got := tag.InsertVertexTemplate("vid")
+	want := "INSERT VERTEX person(name,age) VALUES $vid:($name,$age)"
+	if got != want {
+		t.Fatalf("InsertVertexTemplate = %q, want %q", got, want)
+	}
+}
+
+func TestInsertEdgeTemplate(t *testing.T) {
+	edge := &Edge{
+		Name: strPtr("knows"),
+		Props: []*Prop{
+			{Name: strPtr("since"), Parameter: strPtr("since")},
+		},
+	}
+	got := edge.InsertEdgeTemplate("src", "dst")
+	want := "INSERT EDGE knows(since) VALUES $src->$dst:($since)"
+	if got != want {
+		t.Fatalf("InsertEdgeTemplate = %q, want %q", got, want)
+	}
+}
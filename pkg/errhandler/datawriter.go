@@ -1,13 +1,17 @@
 package errhandler
 
 import (
-	"os"
+	"io"
 
 	"github.com/vesoft-inc/nebula-importer/v3/pkg/base"
 )
 
+// DataWriter is the sink for rows that failed to import. Init takes an
+// io.Writer rather than a concrete *os.File so the error output can be
+// pointed at anything that can be written to: a local file, an in-memory
+// buffer, or an adapter wrapping a non-file sink such as Kafka.
 type DataWriter interface {
-	Init(*os.File)
+	Init(io.Writer) error
 	Write([]base.Data)
 	Flush()
 	Error() error
@@ -0,0 +1,28 @@
+package errhandler
+
+import "fmt"
+
+// NewDataWriter selects a DataWriter implementation by the error.format
+// config key (csv, jsonl, parquet, kafka). kafkaProducer is only consulted
+// when format is "kafka"; parquetRows is only consulted when format is
+// "parquet".
+func NewDataWriter(format string, kafkaProducer KafkaProducer, parquetRows ParquetRowWriter) (DataWriter, error) {
+	switch format {
+	case "", "csv":
+		return NewCsvErrWriter(), nil
+	case "jsonl":
+		return NewJSONLinesWriter(), nil
+	case "parquet":
+		if parquetRows == nil {
+			return nil, fmt.Errorf("error.format is parquet but no ParquetRowWriter was configured")
+		}
+		return NewParquetWriter(parquetRows), nil
+	case "kafka":
+		if kafkaProducer == nil {
+			return nil, fmt.Errorf("error.format is kafka but no Kafka producer was configured")
+		}
+		return NewKafkaWriter(kafkaProducer), nil
+	default:
+		return nil, fmt.Errorf("unsupported error.format: %s", format)
+	}
+}
@@ -0,0 +1,35 @@
+package errhandler
+
+import "testing"
+
+func TestNewDataWriter(t *testing.T) {
+	tests := []struct {
+		format  string
+		wantErr bool
+	}{
+		{format: "", wantErr: false},
+		{format: "csv", wantErr: false},
+		{format: "jsonl", wantErr: false},
+		{format: "parquet", wantErr: true}, // no ParquetRowWriter configured
+		{format: "kafka", wantErr: true},   // no KafkaProducer configured
+		{format: "xml", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			w, err := NewDataWriter(tt.format, nil, nil)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("NewDataWriter(%q) returned nil error, want error", tt.format)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewDataWriter(%q) returned error: %v", tt.format, err)
+			}
+			if w == nil {
+				t.Fatalf("NewDataWriter(%q) returned nil writer", tt.format)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package errhandler
+
+import (
+	"encoding/csv"
+	"io"
+
+	"github.com/vesoft-inc/nebula-importer/v3/pkg/base"
+)
+
+// CsvErrWriter is the default DataWriter: it re-renders each failed row as a
+// CSV line, prefixed with "+"/"-" for INSERT/DELETE so the file can be fed
+// straight back into the importer to retry.
+type CsvErrWriter struct {
+	writer *csv.Writer
+	err    error
+}
+
+func NewCsvErrWriter() *CsvErrWriter {
+	return &CsvErrWriter{}
+}
+
+func (w *CsvErrWriter) Init(out io.Writer) error {
+	w.writer = csv.NewWriter(out)
+	return nil
+}
+
+func (w *CsvErrWriter) Write(data []base.Data) {
+	if w.err != nil {
+		return
+	}
+	for _, d := range data {
+		var label string
+		switch d.Type {
+		case base.INSERT:
+			label = "+"
+		case base.DELETE:
+			label = "-"
+		default:
+			continue
+		}
+		record := append([]string{label}, d.Record...)
+		if err := w.writer.Write(record); err != nil {
+			w.err = err
+			return
+		}
+	}
+}
+
+func (w *CsvErrWriter) Flush() {
+	w.writer.Flush()
+	if err := w.writer.Error(); err != nil && w.err == nil {
+		w.err = err
+	}
+}
+
+func (w *CsvErrWriter) Error() error {
+	return w.err
+}
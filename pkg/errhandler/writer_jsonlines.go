@@ -0,0 +1,49 @@
+package errhandler
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/vesoft-inc/nebula-importer/v3/pkg/base"
+)
+
+// failedRow is the JSON Lines record written for each failed base.Data row,
+// carrying its original column headers so the line is enough to re-drive
+// the import without access to the source CSV.
+type failedRow struct {
+	Headers []string `json:"headers"`
+	Values  []string `json:"values"`
+}
+
+// JSONLinesWriter writes one failedRow per line as newline-delimited JSON.
+type JSONLinesWriter struct {
+	enc *json.Encoder
+	err error
+}
+
+func NewJSONLinesWriter() *JSONLinesWriter {
+	return &JSONLinesWriter{}
+}
+
+func (w *JSONLinesWriter) Init(out io.Writer) error {
+	w.enc = json.NewEncoder(out)
+	return nil
+}
+
+func (w *JSONLinesWriter) Write(data []base.Data) {
+	if w.err != nil {
+		return
+	}
+	for _, d := range data {
+		if err := w.enc.Encode(failedRow{Headers: d.Headers, Values: d.Record}); err != nil {
+			w.err = err
+			return
+		}
+	}
+}
+
+func (w *JSONLinesWriter) Flush() {}
+
+func (w *JSONLinesWriter) Error() error {
+	return w.err
+}
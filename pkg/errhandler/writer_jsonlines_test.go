@@ -0,0 +1,34 @@
+package errhandler
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/vesoft-inc/nebula-importer/v3/pkg/base"
+)
+
+func TestJSONLinesWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewJSONLinesWriter()
+	if err := w.Init(&buf); err != nil {
+		t.Fatalf("Init returned error: %v", err)
+	}
+
+	d := base.InsertData(base.Record{"bob", "30"}, 2)
+	d.Headers = []string{"name", "age"}
+	w.Write([]base.Data{d})
+	w.Flush()
+
+	if err := w.Error(); err != nil {
+		t.Fatalf("Error() = %v, want nil", err)
+	}
+
+	var got failedRow
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("failed to unmarshal output: %v", err)
+	}
+	if got.Headers[0] != "name" || got.Values[0] != "bob" {
+		t.Errorf("got %+v, want headers=[name age] values=[bob 30]", got)
+	}
+}
@@ -0,0 +1,58 @@
+package errhandler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/vesoft-inc/nebula-importer/v3/pkg/base"
+)
+
+// KafkaProducer is the subset of a Kafka client KafkaWriter needs. It is
+// defined here, rather than importing a concrete client, so tests can fake
+// it and callers can plug in whichever Kafka library they already use.
+type KafkaProducer interface {
+	Produce(key, value []byte) error
+}
+
+// KafkaWriter republishes failed rows to Kafka instead of writing them to a
+// file, keyed by their source file and line number so consumers can dedupe
+// and trace a message back to its origin.
+type KafkaWriter struct {
+	producer KafkaProducer
+	err      error
+}
+
+func NewKafkaWriter(producer KafkaProducer) *KafkaWriter {
+	return &KafkaWriter{producer: producer}
+}
+
+// Init is a no-op: KafkaWriter publishes through producer, not out.
+func (w *KafkaWriter) Init(out io.Writer) error {
+	return nil
+}
+
+func (w *KafkaWriter) Write(data []base.Data) {
+	if w.err != nil {
+		return
+	}
+	for _, d := range data {
+		key := []byte(fmt.Sprintf("%s:%d", d.Source, d.LineNo))
+		value, err := json.Marshal(failedRow{Headers: d.Headers, Values: d.Record})
+		if err != nil {
+			w.err = err
+			return
+		}
+		if err := w.producer.Produce(key, value); err != nil {
+			w.err = err
+			return
+		}
+	}
+}
+
+// Flush is a no-op: KafkaWriter produces each message as it is written.
+func (w *KafkaWriter) Flush() {}
+
+func (w *KafkaWriter) Error() error {
+	return w.err
+}
@@ -0,0 +1,74 @@
+package errhandler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/vesoft-inc/nebula-importer/v3/pkg/base"
+)
+
+type fakeKafkaProducer struct {
+	keys     [][]byte
+	values   [][]byte
+	failAt   int
+	produced int
+}
+
+func (p *fakeKafkaProducer) Produce(key, value []byte) error {
+	if p.failAt > 0 && p.produced == p.failAt-1 {
+		p.produced++
+		return errors.New("produce failed")
+	}
+	p.keys = append(p.keys, key)
+	p.values = append(p.values, value)
+	p.produced++
+	return nil
+}
+
+func TestKafkaWriterWrite(t *testing.T) {
+	producer := &fakeKafkaProducer{}
+	w := NewKafkaWriter(producer)
+	if err := w.Init(nil); err != nil {
+		t.Fatalf("Init returned error: %v", err)
+	}
+
+	d1 := base.InsertData(base.Record{"a", "1"}, 2)
+	d1.Headers = []string{"name", "age"}
+	d1.Source = "people.csv"
+	d1.LineNo = 3
+	w.Write([]base.Data{d1})
+	w.Flush()
+
+	if err := w.Error(); err != nil {
+		t.Fatalf("Error() = %v, want nil", err)
+	}
+	if len(producer.keys) != 1 {
+		t.Fatalf("produced %d messages, want 1", len(producer.keys))
+	}
+	if wantKey := fmt.Sprintf("%s:%d", d1.Source, d1.LineNo); string(producer.keys[0]) != wantKey {
+		t.Errorf("key = %q, want %q", producer.keys[0], wantKey)
+	}
+
+	var got failedRow
+	if err := json.Unmarshal(producer.values[0], &got); err != nil {
+		t.Fatalf("failed to unmarshal value: %v", err)
+	}
+	if got.Headers[0] != "name" || got.Values[0] != "a" {
+		t.Errorf("got %+v, want headers=[name age] values=[a 1]", got)
+	}
+}
+
+func TestKafkaWriterProduceError(t *testing.T) {
+	producer := &fakeKafkaProducer{failAt: 1}
+	w := NewKafkaWriter(producer)
+	if err := w.Init(nil); err != nil {
+		t.Fatalf("Init returned error: %v", err)
+	}
+
+	w.Write([]base.Data{base.InsertData(base.Record{"a"}, 1)})
+	if err := w.Error(); err == nil {
+		t.Fatal("Error() = nil, want produce error")
+	}
+}
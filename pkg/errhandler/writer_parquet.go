@@ -0,0 +1,62 @@
+package errhandler
+
+import (
+	"io"
+
+	"github.com/vesoft-inc/nebula-importer/v3/pkg/base"
+)
+
+// ParquetRowWriter is the subset of a Parquet client ParquetWriter needs. It
+// is defined here, rather than importing a concrete client, so tests can
+// fake it and callers can plug in whichever Parquet library they already
+// use.
+type ParquetRowWriter interface {
+	WriteRow(row interface{}) error
+	Close() error
+}
+
+// ParquetWriter writes one failedRow per failed row through rows. Unlike
+// Csv/JSONLinesWriter, Flush closes rows (the underlying library closes its
+// output on Close), so only the first Flush has any effect: later calls are
+// no-ops rather than a second Close, and rows passed to Write after the
+// first Flush go to the already-closed rows.
+type ParquetWriter struct {
+	rows   ParquetRowWriter
+	closed bool
+	err    error
+}
+
+func NewParquetWriter(rows ParquetRowWriter) *ParquetWriter {
+	return &ParquetWriter{rows: rows}
+}
+
+// Init is a no-op: ParquetWriter writes through rows, not out.
+func (w *ParquetWriter) Init(out io.Writer) error {
+	return nil
+}
+
+func (w *ParquetWriter) Write(data []base.Data) {
+	if w.err != nil {
+		return
+	}
+	for _, d := range data {
+		if err := w.rows.WriteRow(failedRow{Headers: d.Headers, Values: d.Record}); err != nil {
+			w.err = err
+			return
+		}
+	}
+}
+
+func (w *ParquetWriter) Flush() {
+	if w.closed {
+		return
+	}
+	w.closed = true
+	if err := w.rows.Close(); err != nil && w.err == nil {
+		w.err = err
+	}
+}
+
+func (w *ParquetWriter) Error() error {
+	return w.err
+}
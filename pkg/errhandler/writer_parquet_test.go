@@ -0,0 +1,89 @@
+package errhandler
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/vesoft-inc/nebula-importer/v3/pkg/base"
+)
+
+type fakeParquetRowWriter struct {
+	rows       []interface{}
+	closeErr   error
+	closeCalls int
+}
+
+func (r *fakeParquetRowWriter) WriteRow(row interface{}) error {
+	r.rows = append(r.rows, row)
+	return nil
+}
+
+func (r *fakeParquetRowWriter) Close() error {
+	r.closeCalls++
+	return r.closeErr
+}
+
+func TestParquetWriterWrite(t *testing.T) {
+	rows := &fakeParquetRowWriter{}
+	w := NewParquetWriter(rows)
+	if err := w.Init(nil); err != nil {
+		t.Fatalf("Init returned error: %v", err)
+	}
+
+	d := base.InsertData(base.Record{"a", "1"}, 2)
+	d.Headers = []string{"name", "age"}
+	w.Write([]base.Data{d})
+	w.Flush()
+
+	if err := w.Error(); err != nil {
+		t.Fatalf("Error() = %v, want nil", err)
+	}
+	if len(rows.rows) != 1 {
+		t.Fatalf("wrote %d rows, want 1", len(rows.rows))
+	}
+	got, ok := rows.rows[0].(failedRow)
+	if !ok {
+		t.Fatalf("row type = %T, want failedRow", rows.rows[0])
+	}
+	if got.Headers[0] != "name" || got.Values[0] != "a" {
+		t.Errorf("got %+v, want headers=[name age] values=[a 1]", got)
+	}
+}
+
+func TestParquetWriterFlushIsIdempotent(t *testing.T) {
+	rows := &fakeParquetRowWriter{}
+	w := NewParquetWriter(rows)
+	if err := w.Init(nil); err != nil {
+		t.Fatalf("Init returned error: %v", err)
+	}
+
+	w.Flush()
+	w.Flush()
+	w.Flush()
+
+	if rows.closeCalls != 1 {
+		t.Errorf("Close called %d times, want 1", rows.closeCalls)
+	}
+	if err := w.Error(); err != nil {
+		t.Fatalf("Error() = %v, want nil", err)
+	}
+}
+
+func TestParquetWriterFlushCloseError(t *testing.T) {
+	rows := &fakeParquetRowWriter{closeErr: errors.New("close failed")}
+	w := NewParquetWriter(rows)
+	if err := w.Init(nil); err != nil {
+		t.Fatalf("Init returned error: %v", err)
+	}
+
+	w.Flush()
+	if err := w.Error(); err == nil {
+		t.Fatal("Error() = nil, want close error")
+	}
+
+	// A second Flush must not re-close or overwrite the recorded error.
+	w.Flush()
+	if rows.closeCalls != 1 {
+		t.Errorf("Close called %d times, want 1", rows.closeCalls)
+	}
+}